@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func dataSourceAwsElasticacheSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Read: dataSourceAwsElasticacheSnapshotRead,
+
+		Schema: map[string]*schema.Schema{
+			"snapshot_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"replication_group_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cache_cluster_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"kms_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"cache_node_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"engine": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"engine_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func dataSourceAwsElasticacheSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	snapshotName := d.Get("snapshot_name").(string)
+	snap, err := elasticacheDescribeSnapshot(conn, snapshotName)
+	if err != nil {
+		return err
+	}
+
+	if snap == nil {
+		return fmt.Errorf("Elasticache snapshot %q not found", snapshotName)
+	}
+
+	d.SetId(snapshotName)
+	d.Set("replication_group_id", snap.ReplicationGroupId)
+	d.Set("cache_cluster_id", snap.CacheClusterId)
+	d.Set("kms_key_id", snap.KmsKeyId)
+	d.Set("cache_node_type", snap.CacheNodeType)
+	d.Set("engine", snap.Engine)
+	d.Set("engine_version", snap.EngineVersion)
+
+	return nil
+}
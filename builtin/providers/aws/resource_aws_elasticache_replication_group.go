@@ -3,6 +3,8 @@ package aws
 import (
 	"fmt"
 	"log"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
@@ -10,6 +12,7 @@ import (
 	"github.com/aws/aws-sdk-go/service/elasticache"
 	"github.com/hashicorp/terraform/helper/resource"
 	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform/terraform"
 )
 
 func resourceAwsElasticacheReplicationGroupCommon() map[string]*schema.Schema {
@@ -41,9 +44,10 @@ func resourceAwsElasticacheReplicationGroup() *schema.Resource {
 	resourceSchema := resourceAwsElasticacheReplicationGroupCommon()
 
 	resourceSchema["number_cache_clusters"] = &schema.Schema{
-		Type:     schema.TypeInt,
-		Required: true,
-		ForceNew: true,
+		Type:          schema.TypeInt,
+		Optional:      true,
+		ForceNew:      true,
+		ConflictsWith: []string{"cluster_mode"},
 	}
 
 	resourceSchema["automatic_failover_enabled"] = &schema.Schema{
@@ -57,89 +61,207 @@ func resourceAwsElasticacheReplicationGroup() *schema.Resource {
 		Computed: true,
 	}
 
+	// node_type was ForceNew in the common cluster/instance schema; Elasticache
+	// now supports scaling an existing replication group in place.
+	resourceSchema["node_type"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		Computed: true,
+	}
+
+	resourceSchema["snapshot_arns"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		ForceNew: true,
+		Elem:     &schema.Schema{Type: schema.TypeString},
+	}
+
+	resourceSchema["snapshot_name"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	resourceSchema["final_snapshot_identifier"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+	}
+
+	resourceSchema["transit_encryption_enabled"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+		Default:  false,
+	}
+
+	resourceSchema["at_rest_encryption_enabled"] = &schema.Schema{
+		Type:     schema.TypeBool,
+		Optional: true,
+		ForceNew: true,
+		Default:  false,
+	}
+
+	resourceSchema["kms_key_id"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Optional: true,
+		ForceNew: true,
+	}
+
+	resourceSchema["auth_token"] = &schema.Schema{
+		Type:      schema.TypeString,
+		Optional:  true,
+		Sensitive: true,
+		DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+			// AWS never returns the AUTH token on read, so old/new are both
+			// empty whenever the attribute isn't tracked in config; nothing
+			// to diff in that case.
+			return old == "" && new == ""
+		},
+	}
+
+	resourceSchema["auth_token_update_strategy"] = &schema.Schema{
+		Type:         schema.TypeString,
+		Optional:     true,
+		Default:      elasticache.AuthTokenUpdateStrategyTypeRotate,
+		ValidateFunc: validateAwsElasticacheReplicationGroupAuthTokenUpdateStrategy,
+	}
+
+	resourceSchema["cluster_mode"] = &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: []string{"number_cache_clusters"},
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"num_node_groups": &schema.Schema{
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+				"replicas_per_node_group": &schema.Schema{
+					Type:     schema.TypeInt,
+					Required: true,
+				},
+			},
+		},
+	}
+
+	resourceSchema["configuration_endpoint_address"] = &schema.Schema{
+		Type:     schema.TypeString,
+		Computed: true,
+	}
+
+	resourceSchema["node_groups"] = &schema.Schema{
+		Type:     schema.TypeList,
+		Computed: true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"node_group_id": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"slots": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"primary_endpoint": &schema.Schema{
+					Type:     schema.TypeString,
+					Computed: true,
+				},
+				"replica_endpoints": &schema.Schema{
+					Type:     schema.TypeList,
+					Computed: true,
+					Elem:     &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+
 	return &schema.Resource{
 		Create: resourceAwsElasticacheReplicationGroupCreate,
 		Read:   resourceAwsElasticacheReplicationGroupRead,
 		Update: resourceAwsElasticacheReplicationGroupUpdate,
 		Delete: resourceAwsElasticacheReplicationGroupDelete,
 
-		Schema: map[string]*schema.Schema{
-			"replication_group_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-				ForceNew: true,
-			},
-			"description": &schema.Schema{
-				Type:     schema.TypeString,
-				Required: true,
-			},
-			"cache_node_type": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
-			"automatic_failover": &schema.Schema{
-				Type:     schema.TypeBool,
-				Optional: true,
-			},
-			"num_cache_clusters": &schema.Schema{
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
-			},
-			"primary_cluster_id": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
-			"parameter_group_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"subnet_group_name": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-			},
-			"security_group_names": &schema.Schema{
-				Type:     schema.TypeSet,
-				Optional: true,
-				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
-			},
-			"security_group_ids": &schema.Schema{
-				Type:     schema.TypeSet,
-				Optional: true,
-				Computed: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
-			},
-			"engine": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-				ForceNew: true,
-				Default:  "redis",
-			},
-			"engine_version": &schema.Schema{
-				Type:     schema.TypeString,
-				Optional: true,
-			},
-			"primary_endpoint": &schema.Schema{
-				Type:     schema.TypeString,
-				Computed: true,
-			},
-			"preferred_cache_cluster_azs": &schema.Schema{
-				Type:     schema.TypeSet,
-				Optional: true,
-				ForceNew: true,
-				Elem:     &schema.Schema{Type: schema.TypeString},
-				Set:      schema.HashString,
-			},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Update: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
+
+		CustomizeDiff: resourceAwsElasticacheReplicationGroupCustomizeDiff,
+
+		SchemaVersion: 1,
+		MigrateState:  resourceAwsElasticacheReplicationGroupMigrateState,
+
+		Schema: resourceSchema,
+	}
+}
+
+// validateAwsElasticacheReplicationGroupAuthTokenUpdateStrategy restricts
+// auth_token_update_strategy to the values ModifyReplicationGroup accepts,
+// so any other value is rejected here before a request is sent to the API.
+func validateAwsElasticacheReplicationGroupAuthTokenUpdateStrategy(v interface{}, k string) (ws []string, errors []error) {
+	value := v.(string)
+	validStrategies := map[string]bool{
+		elasticache.AuthTokenUpdateStrategyTypeSet:    true,
+		elasticache.AuthTokenUpdateStrategyTypeRotate: true,
+		elasticache.AuthTokenUpdateStrategyTypeDelete: true,
+	}
+
+	if !validStrategies[value] {
+		errors = append(errors, fmt.Errorf(
+			"%q must be one of %q, %q, or %q, got %q", k,
+			elasticache.AuthTokenUpdateStrategyTypeSet,
+			elasticache.AuthTokenUpdateStrategyTypeRotate,
+			elasticache.AuthTokenUpdateStrategyTypeDelete,
+			value))
+	}
+
+	return
+}
+
+// resourceAwsElasticacheReplicationGroupCustomizeDiff forces recreation when
+// cluster_mode is toggled on or off an existing replication group. Cluster
+// mode cannot be enabled or disabled in place; only the shard count of an
+// already cluster-mode-enabled group can be changed via Update.
+func resourceAwsElasticacheReplicationGroupCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+	o, n := diff.GetChange("cluster_mode")
+	oldEnabled := len(o.([]interface{})) > 0
+	newEnabled := len(n.([]interface{})) > 0
+
+	if oldEnabled != newEnabled {
+		return diff.ForceNew("cluster_mode")
+	}
+
+	return nil
+}
+
+// resourceAwsElasticacheReplicationGroupMigrateState handles the v0 -> v1
+// rename of cache_node_type to node_type, which aligns the schema with the
+// field name already used by CreateSetup/Read.
+func resourceAwsElasticacheReplicationGroupMigrateState(v int, is *terraform.InstanceState, meta interface{}) (*terraform.InstanceState, error) {
+	switch v {
+	case 0:
+		log.Println("[INFO] Found Elasticache Replication Group State v0; migrating to v1")
+		return migrateElasticacheReplicationGroupStateV0toV1(is)
+	default:
+		return is, fmt.Errorf("Unexpected schema version: %d", v)
 	}
 }
 
+func migrateElasticacheReplicationGroupStateV0toV1(is *terraform.InstanceState) (*terraform.InstanceState, error) {
+	if is.Empty() {
+		return is, nil
+	}
+
+	if v, ok := is.Attributes["cache_node_type"]; ok {
+		is.Attributes["node_type"] = v
+		delete(is.Attributes, "cache_node_type")
+	}
+
+	return is, nil
+}
+
 func resourceAwsElasticacheReplicationGroupCreateSetup(d *schema.ResourceData, meta interface{}) *elasticache.CreateReplicationGroupInput {
 
 	tags := tagsFromMapEC(d.Get("tags").(map[string]interface{}))
@@ -157,10 +279,55 @@ func resourceAwsElasticacheReplicationGroupCreateSetup(d *schema.ResourceData, m
 		params.NumCacheClusters = aws.Int64(int64(v.(int)))
 	}
 
+	if v, ok := d.GetOk("cluster_mode"); ok {
+		clusterModeList := v.([]interface{})
+		attributes := clusterModeList[0].(map[string]interface{})
+
+		if v, ok := attributes["num_node_groups"]; ok {
+			params.NumNodeGroups = aws.Int64(int64(v.(int)))
+		}
+
+		if v, ok := attributes["replicas_per_node_group"]; ok {
+			params.ReplicasPerNodeGroup = aws.Int64(int64(v.(int)))
+		}
+	}
+
 	if v, ok := d.GetOk("engine_version"); ok {
 		params.EngineVersion = aws.String(v.(string))
 	}
 
+	if v, ok := d.GetOk("snapshot_arns"); ok {
+		params.SnapshotArns = expandStringList(v.([]interface{}))
+	}
+
+	if v, ok := d.GetOk("snapshot_name"); ok {
+		params.SnapshotName = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("snapshot_window"); ok {
+		params.SnapshotWindow = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("snapshot_retention_limit"); ok {
+		params.SnapshotRetentionLimit = aws.Int64(int64(v.(int)))
+	}
+
+	if v, ok := d.GetOk("transit_encryption_enabled"); ok {
+		params.TransitEncryptionEnabled = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("at_rest_encryption_enabled"); ok {
+		params.AtRestEncryptionEnabled = aws.Bool(v.(bool))
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		params.KmsKeyId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("auth_token"); ok {
+		params.AuthToken = aws.String(v.(string))
+	}
+
 	preferred_azs := d.Get("availability_zones").(*schema.Set).List()
 	if len(preferred_azs) > 0 {
 		azs := expandStringList(preferred_azs)
@@ -193,7 +360,7 @@ func resourceAwsElasticacheReplicationGroupCreateCommon(d *schema.ResourceData,
 		Pending:    pending,
 		Target:     []string{"available"},
 		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "available", pending),
-		Timeout:    60 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutCreate),
 		Delay:      20 * time.Second,
 		MinTimeout: 5 * time.Second,
 	}
@@ -208,10 +375,71 @@ func resourceAwsElasticacheReplicationGroupCreateCommon(d *schema.ResourceData,
 }
 
 func resourceAwsElasticacheReplicationGroupCreate(d *schema.ResourceData, meta interface{}) error {
+	if err := validateAwsElasticacheReplicationGroupEncryptionSettings(d); err != nil {
+		return err
+	}
+
 	params := resourceAwsElasticacheReplicationGroupCreateSetup(d, meta)
 	return resourceAwsElasticacheReplicationGroupCreateCommon(d, meta, params)
 }
 
+// validateAwsElasticacheReplicationGroupEncryptionSettings enforces that
+// in-transit encryption, at-rest encryption, and AUTH tokens are only
+// requested for Redis replication groups running an engine version new
+// enough to support them.
+func validateAwsElasticacheReplicationGroupEncryptionSettings(d *schema.ResourceData) error {
+	_, hasKmsKeyId := d.GetOk("kms_key_id")
+	_, hasAuthToken := d.GetOk("auth_token")
+	transitEncryption := d.Get("transit_encryption_enabled").(bool)
+	atRestEncryption := d.Get("at_rest_encryption_enabled").(bool)
+
+	if !transitEncryption && !atRestEncryption && !hasKmsKeyId && !hasAuthToken {
+		return nil
+	}
+
+	if hasAuthToken && !transitEncryption {
+		return fmt.Errorf("auth_token requires transit_encryption_enabled = true")
+	}
+
+	if engine := d.Get("engine").(string); engine != "redis" {
+		return fmt.Errorf("transit_encryption_enabled, at_rest_encryption_enabled, kms_key_id, and auth_token "+
+			"require engine = \"redis\", got %q", engine)
+	}
+
+	minVersion := "3.2.6"
+	if hasKmsKeyId {
+		minVersion = "4.0.0"
+	}
+
+	if engineVersion := d.Get("engine_version").(string); engineVersion != "" && !elasticacheEngineVersionAtLeast(engineVersion, minVersion) {
+		return fmt.Errorf("transit_encryption_enabled, at_rest_encryption_enabled, kms_key_id, and auth_token "+
+			"require engine_version >= %s, got %q", minVersion, engineVersion)
+	}
+
+	return nil
+}
+
+// elasticacheEngineVersionAtLeast compares dotted Redis engine version
+// strings (e.g. "3.2.6") numerically, component by component.
+func elasticacheEngineVersionAtLeast(version, min string) bool {
+	v := strings.Split(version, ".")
+	m := strings.Split(min, ".")
+
+	for i := 0; i < len(m); i++ {
+		var vPart, mPart int
+		if i < len(v) {
+			vPart, _ = strconv.Atoi(v[i])
+		}
+		mPart, _ = strconv.Atoi(m[i])
+
+		if vPart != mPart {
+			return vPart > mPart
+		}
+	}
+
+	return true
+}
+
 func resourceAwsElasticacheReplicationGroupRead(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).elasticacheconn
 
@@ -236,22 +464,30 @@ func resourceAwsElasticacheReplicationGroupRead(d *schema.ResourceData, meta int
 			return nil
 		}
 		d.Set("replication_group_id", c.ReplicationGroupId)
-		d.Set("description", c.Description)
-		d.Set("automatic_failover", c.AutomaticFailover)
-		d.Set("num_cache_clusters", len(c.MemberClusters))
+		d.Set("replication_group_description", c.Description)
+		d.Set("node_type", c.CacheNodeType)
+		d.Set("automatic_failover_enabled", c.AutomaticFailover)
+		d.Set("number_cache_clusters", len(c.MemberClusters))
 		if len(c.NodeGroups) >= 1 && c.NodeGroups[0].PrimaryEndpoint != nil {
-			d.Set("primary_endpoint", c.NodeGroups[0].PrimaryEndpoint.Address)
+			d.Set("primary_endpoint_address", c.NodeGroups[0].PrimaryEndpoint.Address)
+			d.Set("port", c.NodeGroups[0].PrimaryEndpoint.Port)
 		}
 		d.Set("maintenance_window", c.PreferredMaintenanceWindow)
 		d.Set("snapshot_window", c.SnapshotWindow)
 		d.Set("snapshot_retention_limit", c.SnapshotRetentionLimit)
 
-		if rgp.NodeGroups[0].PrimaryEndpoint != nil {
-			d.Set("port", rgp.NodeGroups[0].PrimaryEndpoint.Port)
-			d.Set("primary_endpoint_address", rgp.NodeGroups[0].PrimaryEndpoint.Address)
-		} else if rgp.NodeGroups[0].Endpoint != nil {
-			d.Set("port", rgp.NodeGroups[0].Endpoint.Port)
-			d.Set("endpoint_address", rgp.NodeGroups[0].Endpoint.Address)
+		if c.ConfigurationEndpoint != nil {
+			d.Set("configuration_endpoint_address", c.ConfigurationEndpoint.Address)
+		}
+
+		if err := d.Set("node_groups", flattenElasticacheNodeGroups(c.NodeGroups)); err != nil {
+			return fmt.Errorf("Error setting node_groups: %s", err)
+		}
+
+		// Populate cluster_mode so an out-of-band reshard or replica count
+		// change shows up as drift on the next plan.
+		if err := d.Set("cluster_mode", flattenElasticacheReplicationGroupClusterMode(c)); err != nil {
+			return fmt.Errorf("Error setting cluster_mode: %s", err)
 		}
 	}
 
@@ -261,46 +497,156 @@ func resourceAwsElasticacheReplicationGroupRead(d *schema.ResourceData, meta int
 func resourceAwsElasticacheReplicationGroupUpdate(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).elasticacheconn
 
+	if d.HasChange("cluster_mode.0.num_node_groups") {
+		o, n := d.GetChange("cluster_mode.0.num_node_groups")
+		oldNumNodeGroups := o.(int)
+		newNumNodeGroups := n.(int)
+
+		if oldNumNodeGroups == 0 || newNumNodeGroups == 0 {
+			return fmt.Errorf("Existing Elasticache Replication Groups cannot toggle Cluster Mode enabled/disabled")
+		}
+
+		err := elasticacheReplicationGroupReshard(conn, d.Id(), newNumNodeGroups, d.Timeout(schema.TimeoutUpdate))
+		if err != nil {
+			return fmt.Errorf("Error resharding Elasticache replication group (%s): %s", d.Id(), err)
+		}
+	}
+
 	req := &elasticache.ModifyReplicationGroupInput{
 		ApplyImmediately:   aws.Bool(true),
 		ReplicationGroupId: aws.String(d.Id()),
 	}
+	requestUpdate := false
 
-	if d.HasChange("automatic_failover") {
-		automaticFailover := d.Get("automatic_failover").(bool)
+	if d.HasChange("automatic_failover_enabled") {
+		automaticFailover := d.Get("automatic_failover_enabled").(bool)
 		req.AutomaticFailoverEnabled = aws.Bool(automaticFailover)
+		requestUpdate = true
 	}
 
-	if d.HasChange("description") {
-		description := d.Get("description").(string)
+	if d.HasChange("replication_group_description") {
+		description := d.Get("replication_group_description").(string)
 		req.ReplicationGroupDescription = aws.String(description)
+		requestUpdate = true
+	}
+
+	if d.HasChange("node_type") {
+		req.CacheNodeType = aws.String(d.Get("node_type").(string))
+		requestUpdate = true
 	}
 
 	if d.HasChange("engine_version") {
-		engineVersion := d.Get("engine_version").(string)
-		req.EngineVersion = aws.String(engineVersion)
+		o, n := d.GetChange("engine_version")
+		oldVersion := o.(string)
+		newVersion := n.(string)
+
+		if oldVersion != "" && !elasticacheEngineVersionAtLeast(newVersion, oldVersion) {
+			return fmt.Errorf("Elasticache replication group (%s) cannot be downgraded from engine_version %q to %q",
+				d.Id(), oldVersion, newVersion)
+		}
+
+		if err := validateElasticacheEngineVersionAvailable(conn, d.Get("engine").(string), newVersion); err != nil {
+			return err
+		}
+
+		req.EngineVersion = aws.String(newVersion)
+		requestUpdate = true
 	}
 
 	if d.HasChange("security_group_ids") {
 		securityIDSet := d.Get("security_group_ids").(*schema.Set)
 		securityIds := expandStringList(securityIDSet.List())
 		req.SecurityGroupIds = securityIds
+		requestUpdate = true
 	}
 
 	if d.HasChange("security_group_names") {
 		securityNameSet := d.Get("security_group_names").(*schema.Set)
 		securityNames := expandStringList(securityNameSet.List())
 		req.CacheSecurityGroupNames = securityNames
+		requestUpdate = true
 	}
 
-	_, err := conn.ModifyReplicationGroup(req)
-	if err != nil {
-		return fmt.Errorf("Error updating Elasticache replication group: %s", err)
+	if d.HasChange("snapshot_window") {
+		req.SnapshotWindow = aws.String(d.Get("snapshot_window").(string))
+		requestUpdate = true
+	}
+
+	if d.HasChange("snapshot_retention_limit") {
+		req.SnapshotRetentionLimit = aws.Int64(int64(d.Get("snapshot_retention_limit").(int)))
+		requestUpdate = true
+	}
+
+	if d.HasChange("auth_token") {
+		newAuthToken := d.Get("auth_token").(string)
+
+		if newAuthToken == "" {
+			// AWS rejects an empty AuthToken paired with any strategy but
+			// DELETE, and DELETE must not be sent alongside a token.
+			req.AuthTokenUpdateStrategy = aws.String(elasticache.AuthTokenUpdateStrategyTypeDelete)
+		} else {
+			if err := validateAwsElasticacheReplicationGroupEncryptionSettings(d); err != nil {
+				return err
+			}
+
+			req.AuthToken = aws.String(newAuthToken)
+			req.AuthTokenUpdateStrategy = aws.String(d.Get("auth_token_update_strategy").(string))
+		}
+
+		requestUpdate = true
+	}
+
+	if requestUpdate {
+		_, err := conn.ModifyReplicationGroup(req)
+		if err != nil {
+			return fmt.Errorf("Error updating Elasticache replication group: %s", err)
+		}
+	}
+
+	if d.HasChange("node_type") || d.HasChange("engine_version") {
+		pending := []string{"modifying"}
+		stateConf := &resource.StateChangeConf{
+			Pending:    pending,
+			Target:     []string{"available"},
+			Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "available", pending),
+			Timeout:    d.Timeout(schema.TimeoutUpdate),
+			Delay:      20 * time.Second,
+			MinTimeout: 5 * time.Second,
+		}
+
+		log.Printf("[DEBUG] Waiting for state to become available: %v", d.Id())
+		_, err := stateConf.WaitForState()
+		if err != nil {
+			return fmt.Errorf("Error waiting for elasticache (%s) to be updated: %s", d.Id(), err)
+		}
 	}
 
 	return resourceAwsElasticacheReplicationGroupRead(d, meta)
 }
 
+// validateElasticacheEngineVersionAvailable verifies that the given engine
+// version exists in the ElastiCache catalog for the given engine, so that a
+// typo'd or retired version is rejected up front rather than failing
+// asynchronously once the API has already accepted the modification. It does
+// not verify that engineVersion is reachable as an upgrade from the
+// replication group's current version; that is handled separately by the
+// downgrade check in Update.
+func validateElasticacheEngineVersionAvailable(conn *elasticache.ElastiCache, engine, engineVersion string) error {
+	resp, err := conn.DescribeCacheEngineVersions(&elasticache.DescribeCacheEngineVersionsInput{
+		Engine:        aws.String(engine),
+		EngineVersion: aws.String(engineVersion),
+	})
+	if err != nil {
+		return fmt.Errorf("Error describing Elasticache engine versions: %s", err)
+	}
+
+	if len(resp.CacheEngineVersions) == 0 {
+		return fmt.Errorf("engine_version %q is not a valid Elasticache engine version for engine %q", engineVersion, engine)
+	}
+
+	return nil
+}
+
 func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta interface{}) error {
 	conn := meta.(*AWSClient).elasticacheconn
 
@@ -308,6 +654,10 @@ func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta i
 		ReplicationGroupId: aws.String(d.Id()),
 	}
 
+	if v, ok := d.GetOk("final_snapshot_identifier"); ok {
+		req.FinalSnapshotIdentifier = aws.String(v.(string))
+	}
+
 	_, err := conn.DeleteReplicationGroup(req)
 	if err != nil {
 		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "ReplicationGroupNotFoundFault" {
@@ -324,7 +674,7 @@ func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta i
 		Pending:    []string{"creating", "available", "deleting"},
 		Target:     []string{""},
 		Refresh:    replicationGroupStateRefreshFunc(conn, d.Id(), "", []string{}),
-		Timeout:    15 * time.Minute,
+		Timeout:    d.Timeout(schema.TimeoutDelete),
 		Delay:      20 * time.Second,
 		MinTimeout: 5 * time.Second,
 	}
@@ -337,6 +687,90 @@ func resourceAwsElasticacheReplicationGroupDelete(d *schema.ResourceData, meta i
 	return nil
 }
 
+func elasticacheReplicationGroupReshard(conn *elasticache.ElastiCache, replicationGroupID string, newNumNodeGroups int, timeout time.Duration) error {
+	req := &elasticache.ModifyReplicationGroupShardConfigurationInput{
+		ApplyImmediately:   aws.Bool(true),
+		NodeGroupCount:     aws.Int64(int64(newNumNodeGroups)),
+		ReplicationGroupId: aws.String(replicationGroupID),
+	}
+
+	log.Printf("[DEBUG] Resharding Elasticache replication group (%s): %s", replicationGroupID, req)
+	_, err := conn.ModifyReplicationGroupShardConfiguration(req)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] Waiting for state to become available: %v", replicationGroupID)
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"modifying", "resharding"},
+		Target:     []string{"available"},
+		Refresh:    replicationGroupStateRefreshFunc(conn, replicationGroupID, "available", []string{"modifying", "resharding"}),
+		Timeout:    timeout,
+		Delay:      20 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for elasticache (%s) to finish resharding: %s", replicationGroupID, err)
+	}
+
+	return nil
+}
+
+func flattenElasticacheNodeGroups(nodeGroups []*elasticache.NodeGroup) []map[string]interface{} {
+	result := make([]map[string]interface{}, 0, len(nodeGroups))
+
+	for _, ng := range nodeGroups {
+		if ng == nil {
+			continue
+		}
+
+		nodeGroup := map[string]interface{}{
+			"slots": aws.StringValue(ng.Slots),
+		}
+
+		if ng.NodeGroupId != nil {
+			nodeGroup["node_group_id"] = aws.StringValue(ng.NodeGroupId)
+		}
+
+		if ng.PrimaryEndpoint != nil {
+			nodeGroup["primary_endpoint"] = aws.StringValue(ng.PrimaryEndpoint.Address)
+		}
+
+		replicaEndpoints := make([]string, 0, len(ng.NodeGroupMembers))
+		for _, member := range ng.NodeGroupMembers {
+			if member == nil || member.ReadEndpoint == nil || aws.StringValue(member.CurrentRole) == "primary" {
+				continue
+			}
+			replicaEndpoints = append(replicaEndpoints, aws.StringValue(member.ReadEndpoint.Address))
+		}
+		nodeGroup["replica_endpoints"] = replicaEndpoints
+
+		result = append(result, nodeGroup)
+	}
+
+	return result
+}
+
+func flattenElasticacheReplicationGroupClusterMode(rg *elasticache.ReplicationGroup) []map[string]interface{} {
+	if rg.ClusterEnabled == nil || !aws.BoolValue(rg.ClusterEnabled) || len(rg.NodeGroups) == 0 {
+		return []map[string]interface{}{}
+	}
+
+	replicasPerNodeGroup := 0
+	if members := len(rg.NodeGroups[0].NodeGroupMembers); members > 0 {
+		replicasPerNodeGroup = members - 1
+	}
+
+	return []map[string]interface{}{
+		{
+			"num_node_groups":         len(rg.NodeGroups),
+			"replicas_per_node_group": replicasPerNodeGroup,
+		},
+	}
+}
+
 func replicationGroupStateRefreshFunc(conn *elasticache.ElastiCache, replicationGroupID, givenState string, pending []string) resource.StateRefreshFunc {
 	return func() (interface{}, string, error) {
 		resp, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
@@ -0,0 +1,266 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSElasticacheReplicationGroup_clusterMode(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	rName := fmt.Sprintf("tf-erg-cluster-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSElasticacheReplicationGroupClusterModeConfig(rName, 2, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "cluster_mode.0.num_node_groups", "2"),
+					resource.TestCheckResourceAttrSet("aws_elasticache_replication_group.test", "configuration_endpoint_address"),
+				),
+			},
+			{
+				// Reshard to a different node group count; the replication
+				// group stays cluster-mode-enabled so this is an in-place
+				// update, not a recreate.
+				Config: testAccAWSElasticacheReplicationGroupClusterModeConfig(rName, 3, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "cluster_mode.0.num_node_groups", "3"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_snapshotRetentionLimit(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	rName := fmt.Sprintf("tf-erg-snapshot-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSElasticacheReplicationGroupSnapshotConfig(rName, 1),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "snapshot_retention_limit", "1"),
+				),
+			},
+			{
+				// snapshot_retention_limit is not ForceNew, so this is an
+				// in-place ModifyReplicationGroup call.
+				Config: testAccAWSElasticacheReplicationGroupSnapshotConfig(rName, 5),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "snapshot_retention_limit", "5"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_authTokenRotateAndDelete(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	rName := fmt.Sprintf("tf-erg-auth-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSElasticacheReplicationGroupAuthTokenConfig(rName, "AVeryVerySecurePassword1"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "transit_encryption_enabled", "true"),
+				),
+			},
+			{
+				// Rotating to a new token issues ModifyReplicationGroup
+				// with AuthTokenUpdateStrategy = ROTATE.
+				Config: testAccAWSElasticacheReplicationGroupAuthTokenConfig(rName, "AnotherVerySecurePassword2"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+				),
+			},
+			{
+				// Clearing auth_token must issue AuthTokenUpdateStrategy =
+				// DELETE rather than an empty AuthToken with ROTATE.
+				Config: testAccAWSElasticacheReplicationGroupAuthTokenDisabledConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+				),
+			},
+		},
+	})
+}
+
+func TestAccAWSElasticacheReplicationGroup_nodeTypeUpdate(t *testing.T) {
+	var rg elasticache.ReplicationGroup
+	rName := fmt.Sprintf("tf-erg-scale-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheReplicationGroupDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSElasticacheReplicationGroupNodeTypeConfig(rName, "cache.m3.medium"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "node_type", "cache.m3.medium"),
+				),
+			},
+			{
+				// node_type is no longer ForceNew, so scaling up must be an
+				// in-place ModifyReplicationGroup call that waits for the
+				// group to return to "available".
+				Config: testAccAWSElasticacheReplicationGroupNodeTypeConfig(rName, "cache.m4.large"),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheReplicationGroupExists("aws_elasticache_replication_group.test", &rg),
+					resource.TestCheckResourceAttr("aws_elasticache_replication_group.test", "node_type", "cache.m4.large"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSElasticacheReplicationGroupExists(n string, v *elasticache.ReplicationGroup) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No replication group ID is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+		resp, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(rs.Primary.ID),
+		})
+		if err != nil {
+			return err
+		}
+
+		if len(resp.ReplicationGroups) != 1 ||
+			*resp.ReplicationGroups[0].ReplicationGroupId != rs.Primary.ID {
+			return fmt.Errorf("Elasticache replication group not found")
+		}
+
+		*v = *resp.ReplicationGroups[0]
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheReplicationGroupDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_elasticache_replication_group" {
+			continue
+		}
+
+		_, err := conn.DescribeReplicationGroups(&elasticache.DescribeReplicationGroupsInput{
+			ReplicationGroupId: aws.String(rs.Primary.ID),
+		})
+		if err == nil {
+			return fmt.Errorf("Elasticache replication group (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSElasticacheReplicationGroupSnapshotConfig(rName string, snapshotRetentionLimit int) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test snapshot lifecycle replication group"
+  node_type                     = "cache.m3.medium"
+  number_cache_clusters         = 1
+  port                          = 6379
+
+  snapshot_window          = "01:00-02:00"
+  snapshot_retention_limit = %d
+}
+`, rName, snapshotRetentionLimit)
+}
+
+func testAccAWSElasticacheReplicationGroupAuthTokenConfig(rName, authToken string) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test auth token replication group"
+  node_type                     = "cache.m3.medium"
+  number_cache_clusters         = 1
+  port                          = 6379
+  engine_version                = "3.2.6"
+
+  transit_encryption_enabled = true
+  auth_token                 = "%s"
+}
+`, rName, authToken)
+}
+
+func testAccAWSElasticacheReplicationGroupAuthTokenDisabledConfig(rName string) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test auth token replication group"
+  node_type                     = "cache.m3.medium"
+  number_cache_clusters         = 1
+  port                          = 6379
+  engine_version                = "3.2.6"
+
+  transit_encryption_enabled = true
+}
+`, rName)
+}
+
+func testAccAWSElasticacheReplicationGroupNodeTypeConfig(rName, nodeType string) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test node type scaling replication group"
+  node_type                     = "%s"
+  number_cache_clusters         = 1
+  port                          = 6379
+
+  timeouts {
+    update = "30m"
+  }
+}
+`, rName, nodeType)
+}
+
+func testAccAWSElasticacheReplicationGroupClusterModeConfig(rName string, numNodeGroups, replicasPerNodeGroup int) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test cluster mode replication group"
+  node_type                     = "cache.m3.medium"
+  engine_version                = "3.2.6"
+  automatic_failover_enabled    = true
+  port                          = 6379
+
+  cluster_mode {
+    num_node_groups         = %d
+    replicas_per_node_group = %d
+  }
+}
+`, rName, numNodeGroups, replicasPerNodeGroup)
+}
@@ -0,0 +1,215 @@
+package aws
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/helper/schema"
+)
+
+func resourceAwsElasticacheSnapshot() *schema.Resource {
+	return &schema.Resource{
+		Create: resourceAwsElasticacheSnapshotCreate,
+		Read:   resourceAwsElasticacheSnapshotRead,
+		Delete: resourceAwsElasticacheSnapshotDelete,
+
+		Schema: map[string]*schema.Schema{
+			"snapshot_name": &schema.Schema{
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"replication_group_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"cache_cluster_id"},
+			},
+			"cache_cluster_id": &schema.Schema{
+				Type:          schema.TypeString,
+				Optional:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"replication_group_id"},
+			},
+			"kms_key_id": &schema.Schema{
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"cache_node_type": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"engine": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"engine_version": &schema.Schema{
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceAwsElasticacheSnapshotCreate(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	snapshotName := d.Get("snapshot_name").(string)
+	params := &elasticache.CreateSnapshotInput{
+		SnapshotName: aws.String(snapshotName),
+	}
+
+	if v, ok := d.GetOk("replication_group_id"); ok {
+		params.ReplicationGroupId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("cache_cluster_id"); ok {
+		params.CacheClusterId = aws.String(v.(string))
+	}
+
+	if v, ok := d.GetOk("kms_key_id"); ok {
+		params.KmsKeyId = aws.String(v.(string))
+	}
+
+	_, err := conn.CreateSnapshot(params)
+	if err != nil {
+		return fmt.Errorf("Error creating Elasticache snapshot: %s", err)
+	}
+
+	d.SetId(snapshotName)
+
+	pending := []string{"creating"}
+	stateConf := &resource.StateChangeConf{
+		Pending:    pending,
+		Target:     []string{"available"},
+		Refresh:    elasticacheSnapshotStateRefreshFunc(conn, snapshotName, "available", pending),
+		Timeout:    30 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	log.Printf("[DEBUG] Waiting for Elasticache snapshot to become available: %v", snapshotName)
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for Elasticache snapshot (%s) to be created: %s", snapshotName, err)
+	}
+
+	return resourceAwsElasticacheSnapshotRead(d, meta)
+}
+
+func resourceAwsElasticacheSnapshotRead(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	snap, err := elasticacheDescribeSnapshot(conn, d.Id())
+	if err != nil {
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "SnapshotNotFoundFault" {
+			d.SetId("")
+			return nil
+		}
+		return err
+	}
+
+	if snap == nil {
+		d.SetId("")
+		return nil
+	}
+
+	d.Set("snapshot_name", snap.SnapshotName)
+	d.Set("replication_group_id", snap.ReplicationGroupId)
+	d.Set("cache_cluster_id", snap.CacheClusterId)
+	d.Set("kms_key_id", snap.KmsKeyId)
+	d.Set("cache_node_type", snap.CacheNodeType)
+	d.Set("engine", snap.Engine)
+	d.Set("engine_version", snap.EngineVersion)
+
+	return nil
+}
+
+func resourceAwsElasticacheSnapshotDelete(d *schema.ResourceData, meta interface{}) error {
+	conn := meta.(*AWSClient).elasticacheconn
+
+	_, err := conn.DeleteSnapshot(&elasticache.DeleteSnapshotInput{
+		SnapshotName: aws.String(d.Id()),
+	})
+	if err != nil {
+		if ec2err, ok := err.(awserr.Error); ok && ec2err.Code() == "SnapshotNotFoundFault" {
+			d.SetId("")
+			return nil
+		}
+		return fmt.Errorf("Error deleting Elasticache snapshot: %s", err)
+	}
+
+	log.Printf("[DEBUG] Waiting for Elasticache snapshot deletion: %v", d.Id())
+	stateConf := &resource.StateChangeConf{
+		Pending:    []string{"creating", "available", "deleting"},
+		Target:     []string{""},
+		Refresh:    elasticacheSnapshotStateRefreshFunc(conn, d.Id(), "", []string{}),
+		Timeout:    15 * time.Minute,
+		Delay:      10 * time.Second,
+		MinTimeout: 5 * time.Second,
+	}
+
+	_, err = stateConf.WaitForState()
+	if err != nil {
+		return fmt.Errorf("Error waiting for Elasticache snapshot (%s) to delete: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+func elasticacheDescribeSnapshot(conn *elasticache.ElastiCache, snapshotName string) (*elasticache.Snapshot, error) {
+	resp, err := conn.DescribeSnapshots(&elasticache.DescribeSnapshotsInput{
+		SnapshotName: aws.String(snapshotName),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(resp.Snapshots) == 0 {
+		return nil, nil
+	}
+
+	return resp.Snapshots[0], nil
+}
+
+func elasticacheSnapshotStateRefreshFunc(conn *elasticache.ElastiCache, snapshotName, givenState string, pending []string) resource.StateRefreshFunc {
+	return func() (interface{}, string, error) {
+		snap, err := elasticacheDescribeSnapshot(conn, snapshotName)
+		if err != nil {
+			if ec2err, ok := err.(awserr.Error); ok {
+				log.Printf("[DEBUG] message: %v, code: %v", ec2err.Message(), ec2err.Code())
+				if ec2err.Code() == "SnapshotNotFoundFault" {
+					log.Printf("[DEBUG] Detect deletion")
+					return nil, "", nil
+				}
+			}
+
+			log.Printf("[ERROR] elasticacheSnapshotStateRefreshFunc: %s", err)
+			return nil, "", err
+		}
+
+		if snap == nil {
+			return nil, "", nil
+		}
+
+		log.Printf("[DEBUG] status: %v", *snap.SnapshotStatus)
+
+		for _, p := range pending {
+			if p == *snap.SnapshotStatus {
+				return snap, p, nil
+			}
+		}
+
+		if givenState != "" {
+			return snap, givenState, nil
+		}
+		return snap, *snap.SnapshotStatus, nil
+	}
+}
@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/service/elasticache"
+	"github.com/hashicorp/terraform/helper/acctest"
+	"github.com/hashicorp/terraform/helper/resource"
+	"github.com/hashicorp/terraform/terraform"
+)
+
+func TestAccAWSElasticacheSnapshot_basic(t *testing.T) {
+	var snap elasticache.Snapshot
+	rName := fmt.Sprintf("tf-erg-snap-%d", acctest.RandInt())
+	snapshotName := fmt.Sprintf("tf-snapshot-%d", acctest.RandInt())
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAWSElasticacheSnapshotDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAWSElasticacheSnapshotConfig(rName, snapshotName),
+				Check: resource.ComposeTestCheckFunc(
+					testAccCheckAWSElasticacheSnapshotExists("aws_elasticache_snapshot.test", &snap),
+					resource.TestCheckResourceAttr("aws_elasticache_snapshot.test", "snapshot_name", snapshotName),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckAWSElasticacheSnapshotExists(n string, v *elasticache.Snapshot) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[n]
+		if !ok {
+			return fmt.Errorf("Not found: %s", n)
+		}
+
+		if rs.Primary.ID == "" {
+			return fmt.Errorf("No snapshot name is set")
+		}
+
+		conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+		snap, err := elasticacheDescribeSnapshot(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if snap == nil {
+			return fmt.Errorf("Elasticache snapshot (%s) not found", rs.Primary.ID)
+		}
+
+		*v = *snap
+		return nil
+	}
+}
+
+func testAccCheckAWSElasticacheSnapshotDestroy(s *terraform.State) error {
+	conn := testAccProvider.Meta().(*AWSClient).elasticacheconn
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "aws_elasticache_snapshot" {
+			continue
+		}
+
+		snap, err := elasticacheDescribeSnapshot(conn, rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		if snap != nil {
+			return fmt.Errorf("Elasticache snapshot (%s) still exists", rs.Primary.ID)
+		}
+	}
+
+	return nil
+}
+
+func testAccAWSElasticacheSnapshotConfig(rName, snapshotName string) string {
+	return fmt.Sprintf(`
+resource "aws_elasticache_replication_group" "test" {
+  replication_group_id          = "%s"
+  replication_group_description = "test snapshot source replication group"
+  node_type                     = "cache.m3.medium"
+  number_cache_clusters         = 1
+  port                          = 6379
+}
+
+resource "aws_elasticache_snapshot" "test" {
+  snapshot_name        = "%s"
+  replication_group_id = "${aws_elasticache_replication_group.test.id}"
+}
+`, rName, snapshotName)
+}